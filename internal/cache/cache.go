@@ -0,0 +1,12 @@
+// Package cache defines a small pluggable cache abstraction used to keep
+// read results (e.g. RetrieveByID/RetrieveAll) fast without going back to
+// Postgres on every call.
+package cache
+
+// Cache is a get/set/delete store. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	Get(key string) (any, bool)
+	Set(key string, value any)
+	Delete(key string)
+}
@@ -0,0 +1,85 @@
+package cache
+
+import "testing"
+
+func TestLRUCache_GetSet(t *testing.T) {
+	c := NewLRUCache(2)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Get on empty cache returned ok=true")
+	}
+
+	c.Set("a", 1)
+	got, ok := c.Get("a")
+	if !ok || got != 1 {
+		t.Fatalf("Get(%q) = (%v, %v), want (1, true)", "a", got, ok)
+	}
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRUCache(2)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // touch a, so b becomes the least recently used
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("b should have been evicted once capacity was exceeded")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("a should still be cached, it was touched most recently")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatalf("c should be cached, it was just inserted")
+	}
+}
+
+func TestLRUCache_NeverExceedsCapacity(t *testing.T) {
+	c := NewLRUCache(3)
+
+	for i := 0; i < 10; i++ {
+		c.Set(string(rune('a'+i)), i)
+	}
+
+	count := 0
+	for i := 0; i < 10; i++ {
+		if _, ok := c.Get(string(rune('a' + i))); ok {
+			count++
+		}
+	}
+	if count != 3 {
+		t.Fatalf("cache holds %d entries, want exactly capacity 3", count)
+	}
+}
+
+func TestLRUCache_Delete(t *testing.T) {
+	c := NewLRUCache(2)
+
+	c.Set("a", 1)
+	c.Delete("a")
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("a should be gone after Delete")
+	}
+
+	// Deleting an absent key is a no-op, not an error.
+	c.Delete("missing")
+}
+
+func TestLRUCache_SetExistingKeyRefreshesRecency(t *testing.T) {
+	c := NewLRUCache(2)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("a", 10) // re-set a, should move it to the front
+	c.Set("c", 3)  // evicts the least recently used, which is now b
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("b should have been evicted")
+	}
+	got, ok := c.Get("a")
+	if !ok || got != 10 {
+		t.Fatalf("Get(%q) = (%v, %v), want (10, true)", "a", got, ok)
+	}
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"frappuccino/internal/models"
+)
+
+// inventoryLister is implemented by the inventory service; kept as a small
+// interface here so the handler can be tested without a real service.
+type inventoryLister interface {
+	GetLeftOvers(opts models.ListOptions) ([]models.InventoryLeftOverItem, int, int, error)
+	History(inventoryID int, from, to *time.Time, reason *models.InventoryTransactionReason) ([]models.InventoryTransaction, error)
+}
+
+type inventoryHandler struct {
+	service inventoryLister
+}
+
+func NewInventoryHandler(service inventoryLister) *inventoryHandler {
+	return &inventoryHandler{service: service}
+}
+
+// RegisterRoutes mounts h's endpoints on mux. The module targets Go 1.21, so
+// routing is done by hand rather than via the method/wildcard patterns
+// ServeMux gained in 1.22.
+func (h *inventoryHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/inventory/leftovers", h.GetLeftOvers)
+	mux.HandleFunc("/inventory/", h.routeByID)
+}
+
+// routeByID dispatches /inventory/{id}/history; anything else under
+// /inventory/ is a 404.
+func (h *inventoryHandler) routeByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseHistoryPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	h.getHistory(w, r, id)
+}
+
+// parseHistoryPath extracts the {id} from a "/inventory/{id}/history" path.
+func parseHistoryPath(path string) (id int, ok bool) {
+	const prefix, suffix = "/inventory/", "/history"
+	if !strings.HasPrefix(path, prefix) || !strings.HasSuffix(path, suffix) {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path, prefix), suffix))
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// GetLeftOvers handles GET /inventory/leftovers?sort=quantity&dir=desc&category=dairy&min_qty=5
+func (h *inventoryHandler) GetLeftOvers(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, totalPages, totalItems, err := h.service.GetLeftOvers(opts)
+	if err != nil {
+		if err == models.ErrInvalidSortColumn {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"items":       items,
+		"total_pages": totalPages,
+		"total_items": totalItems,
+	})
+}
+
+// getHistory handles GET /inventory/{id}/history?from=&to=&reason=, with id
+// already parsed out of the path by routeByID.
+func (h *inventoryHandler) getHistory(w http.ResponseWriter, r *http.Request, id int) {
+	from, to, err := parseTimeRange(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var reason *models.InventoryTransactionReason
+	if raw := r.URL.Query().Get("reason"); raw != "" {
+		rs := models.InventoryTransactionReason(raw)
+		reason = &rs
+	}
+
+	history, err := h.service.History(id, from, to, reason)
+	if err != nil {
+		if err == models.ErrNoRecord {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// parseTimeRange parses the optional RFC3339 "from"/"to" query parameters.
+func parseTimeRange(q url.Values) (from, to *time.Time, err error) {
+	if raw := q.Get("from"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		from = &t
+	}
+	if raw := q.Get("to"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		to = &t
+	}
+	return from, to, nil
+}
+
+// parseListOptions turns leftover/inventory query parameters into a
+// models.ListOptions, applying defaults for anything omitted.
+func parseListOptions(q url.Values) (models.ListOptions, error) {
+	opts := models.DefaultListOptions()
+
+	if sort := q.Get("sort"); sort != "" {
+		opts.Sort = models.SortColumn(sort)
+	}
+	if dir := q.Get("dir"); dir != "" {
+		opts.Dir = models.SortDirection(dir)
+	}
+	if page := q.Get("page"); page != "" {
+		p, err := strconv.Atoi(page)
+		if err != nil || p < 1 {
+			return models.ListOptions{}, invalidParamError("page")
+		}
+		opts.Page = p
+	}
+	if pageSize := firstNonEmpty(q.Get("page_size"), q.Get("size")); pageSize != "" {
+		ps, err := strconv.Atoi(pageSize)
+		if err != nil || ps < 1 {
+			return models.ListOptions{}, invalidParamError("page_size")
+		}
+		opts.PageSize = ps
+	}
+	if category := q.Get("category"); category != "" {
+		opts.Category = category
+	}
+	if name := q.Get("name"); name != "" {
+		opts.NameLike = name
+	}
+	if minQty := q.Get("min_qty"); minQty != "" {
+		v, err := strconv.Atoi(minQty)
+		if err != nil {
+			return models.ListOptions{}, invalidParamError("min_qty")
+		}
+		opts.MinQty = &v
+	}
+	if maxQty := q.Get("max_qty"); maxQty != "" {
+		v, err := strconv.Atoi(maxQty)
+		if err != nil {
+			return models.ListOptions{}, invalidParamError("max_qty")
+		}
+		opts.MaxQty = &v
+	}
+
+	return opts, nil
+}
+
+// invalidParamError reports that the named query parameter was not a valid
+// integer, rather than reusing models.ErrInvalidID (which names "id"
+// specifically and would mislabel these listing filters).
+func invalidParamError(param string) error {
+	return fmt.Errorf("%s must be a valid integer", param)
+}
+
+// firstNonEmpty returns the first non-empty value, so page_size and the
+// shorter size alias can share one parse path.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
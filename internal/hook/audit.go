@@ -0,0 +1,30 @@
+// Package hook provides built-in repository.Hook implementations wired up
+// through the service layer's WithHook option.
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"frappuccino/internal/repository"
+)
+
+// NewAuditHook returns a repository.Hook that writes a structured slog entry
+// for every mutation it's registered against, recording actor, table, and a
+// before/after JSON diff of the affected record.
+func NewAuditHook(logger *slog.Logger, actor string) repository.Hook {
+	return func(ctx context.Context, op repository.HookOp) error {
+		before, _ := json.Marshal(op.Before)
+		after, _ := json.Marshal(op.After)
+
+		logger.Info("audit",
+			"actor", actor,
+			"table", op.Table,
+			"id", op.ID,
+			"before", string(before),
+			"after", string(after),
+		)
+		return nil
+	}
+}
@@ -0,0 +1,21 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+
+	"frappuccino/internal/cache"
+	"frappuccino/internal/repository"
+)
+
+// NewCacheInvalidationHook returns a repository.Hook that evicts op's row
+// (keyed "<table>:<id>") and the table's cached listing (keyed
+// "<table>:all") from c, so a cache fronting RetrieveByID/RetrieveAll never
+// serves a value an Insert/Update/Delete has just made stale.
+func NewCacheInvalidationHook(c cache.Cache) repository.Hook {
+	return func(ctx context.Context, op repository.HookOp) error {
+		c.Delete(fmt.Sprintf("%s:%d", op.Table, op.ID))
+		c.Delete(fmt.Sprintf("%s:all", op.Table))
+		return nil
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+import "errors"
+
+var (
+	ErrNoRecord                 = errors.New("models: no matching record found")
+	ErrDuplicateInventory       = errors.New("models: inventory item with this name already exists")
+	ErrNegativeQuantity         = errors.New("models: operation would result in negative quantity")
+	ErrInvalidEnumTypeInventory = errors.New("models: invalid enum value for inventory field")
+	ErrMissingFields            = errors.New("models: one or more required fields are missing or invalid")
+	ErrInvalidID                = errors.New("models: id must be a valid integer")
+	ErrInvalidSortColumn        = errors.New("models: sort column is not in the allow-list")
+)
@@ -0,0 +1,17 @@
+package models
+
+// Inventory is a single stock-keeping item.
+type Inventory struct {
+	ID         int
+	Name       string
+	Quantity   int
+	Unit       string
+	Categories []string
+}
+
+// InventoryLeftOverItem is the projection returned by leftover reports
+// (GetLeftOvers).
+type InventoryLeftOverItem struct {
+	Name     string
+	Quantity int
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// InventoryTransactionReason classifies why an inventory quantity changed.
+type InventoryTransactionReason string
+
+const (
+	ReasonRestock    InventoryTransactionReason = "restock"
+	ReasonSale       InventoryTransactionReason = "sale"
+	ReasonAdjustment InventoryTransactionReason = "adjustment"
+	ReasonWaste      InventoryTransactionReason = "waste"
+)
+
+// InventoryTransaction is a single append-only ledger row recording a stock
+// movement. Quantity is never mutated without a corresponding row.
+type InventoryTransaction struct {
+	ID          int
+	InventoryID int
+	Delta       int
+	Reason      InventoryTransactionReason
+	OrderID     *int
+	Actor       string
+	CreatedAt   time.Time
+}
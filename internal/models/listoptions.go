@@ -0,0 +1,44 @@
+package models
+
+// SortColumn enumerates the inventory columns callers are allowed to sort
+// by. Keeping it a closed enum lets the repository layer whitelist it before
+// ever touching SQL.
+type SortColumn string
+
+const (
+	SortByName     SortColumn = "name"
+	SortByQuantity SortColumn = "quantity"
+	SortByUnit     SortColumn = "unit"
+)
+
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// ListOptions carries the sort, paging and filter parameters shared by the
+// inventory listing endpoints (?sort=quantity&dir=desc&category=dairy&min_qty=5).
+type ListOptions struct {
+	Sort     SortColumn
+	Dir      SortDirection
+	Page     int
+	PageSize int
+
+	Category string
+	MinQty   *int
+	MaxQty   *int
+	NameLike string
+}
+
+// DefaultListOptions returns the options used when a caller omits sort/paging
+// query parameters entirely.
+func DefaultListOptions() ListOptions {
+	return ListOptions{
+		Sort:     SortByName,
+		Dir:      SortAsc,
+		Page:     1,
+		PageSize: 20,
+	}
+}
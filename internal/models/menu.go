@@ -0,0 +1,37 @@
+package models
+
+// MenuItem is a single sellable item on the menu.
+type MenuItem struct {
+	ID          int
+	Name        string
+	Description string
+	Price       float64
+	Categories  []string
+}
+
+// MenuItemValidator validates a MenuItem before it is persisted.
+type MenuItemValidator struct {
+	menu MenuItem
+}
+
+func NewMenuItemValidator(menu MenuItem) *MenuItemValidator {
+	return &MenuItemValidator{menu: menu}
+}
+
+// Validate returns a map of field -> error message for any invalid field, or
+// nil if the menu item is valid.
+func (v *MenuItemValidator) Validate() map[string]string {
+	errs := make(map[string]string)
+
+	if v.menu.Name == "" {
+		errs["name"] = "name is required"
+	}
+	if v.menu.Price <= 0 {
+		errs["price"] = "price must be greater than zero"
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,15 @@
+package models
+
+// Order is a customer order composed of one or more menu items.
+type Order struct {
+	ID         int
+	CustomerID int
+	Items      []OrderItem
+	Status     string
+}
+
+// OrderItem is a single line item within an Order.
+type OrderItem struct {
+	MenuItemID int
+	Quantity   int
+}
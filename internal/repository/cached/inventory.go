@@ -0,0 +1,74 @@
+// Package cached wraps repository.InventoryRepository/MenuRepository with a
+// read-through cache.Cache, so RetrieveByID/RetrieveAll can skip the trip to
+// Postgres on a hit. Entries are keyed the same way hook.NewCacheInvalidationHook
+// evicts them ("<table>:<id>" and "<table>:all"), so registering that hook
+// alongside one of these decorators keeps the two in sync.
+package cached
+
+import (
+	"fmt"
+
+	"frappuccino/internal/cache"
+	"frappuccino/internal/models"
+	"frappuccino/internal/repository"
+)
+
+// InventoryRepository adds a read-through cache in front of another
+// repository.InventoryRepository. Every method other than RetrieveByID and
+// RetrieveAll is forwarded unchanged via the embedded interface.
+type InventoryRepository struct {
+	repository.InventoryRepository
+	cache cache.Cache
+}
+
+// NewInventoryRepository wraps repo with a read-through cache backed by c.
+func NewInventoryRepository(repo repository.InventoryRepository, c cache.Cache) *InventoryRepository {
+	return &InventoryRepository{InventoryRepository: repo, cache: c}
+}
+
+func (r *InventoryRepository) RetrieveByID(id int) (models.Inventory, error) {
+	key := fmt.Sprintf("inventory:%d", id)
+	if v, ok := r.cache.Get(key); ok {
+		return v.(models.Inventory), nil
+	}
+
+	item, err := r.InventoryRepository.RetrieveByID(id)
+	if err != nil {
+		return item, err
+	}
+
+	r.cache.Set(key, item)
+	return item, nil
+}
+
+// inventoryPage is what gets stored under the "inventory:all" cache key, so
+// a hit can return all three RetrieveAll results without a second query.
+type inventoryPage struct {
+	items      []models.Inventory
+	totalPages int
+	totalItems int
+}
+
+// RetrieveAll only caches the default, unfiltered listing — the one key
+// hook.NewCacheInvalidationHook evicts on every write. Any other
+// models.ListOptions bypasses the cache rather than risk serving a page that
+// doesn't match the caller's filter/sort/paging.
+func (r *InventoryRepository) RetrieveAll(opts models.ListOptions) ([]models.Inventory, int, int, error) {
+	if opts != models.DefaultListOptions() {
+		return r.InventoryRepository.RetrieveAll(opts)
+	}
+
+	const key = "inventory:all"
+	if v, ok := r.cache.Get(key); ok {
+		page := v.(inventoryPage)
+		return page.items, page.totalPages, page.totalItems, nil
+	}
+
+	items, totalPages, totalItems, err := r.InventoryRepository.RetrieveAll(opts)
+	if err != nil {
+		return items, totalPages, totalItems, err
+	}
+
+	r.cache.Set(key, inventoryPage{items: items, totalPages: totalPages, totalItems: totalItems})
+	return items, totalPages, totalItems, nil
+}
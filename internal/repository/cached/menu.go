@@ -0,0 +1,52 @@
+package cached
+
+import (
+	"fmt"
+
+	"frappuccino/internal/cache"
+	"frappuccino/internal/models"
+	"frappuccino/internal/repository"
+)
+
+// MenuRepository adds a read-through cache in front of another
+// repository.MenuRepository. Every method other than RetrieveByID and
+// RetrieveAll is forwarded unchanged via the embedded interface.
+type MenuRepository struct {
+	repository.MenuRepository
+	cache cache.Cache
+}
+
+// NewMenuRepository wraps repo with a read-through cache backed by c.
+func NewMenuRepository(repo repository.MenuRepository, c cache.Cache) *MenuRepository {
+	return &MenuRepository{MenuRepository: repo, cache: c}
+}
+
+func (r *MenuRepository) RetrieveByID(id int) (models.MenuItem, error) {
+	key := fmt.Sprintf("menu_items:%d", id)
+	if v, ok := r.cache.Get(key); ok {
+		return v.(models.MenuItem), nil
+	}
+
+	item, err := r.MenuRepository.RetrieveByID(id)
+	if err != nil {
+		return item, err
+	}
+
+	r.cache.Set(key, item)
+	return item, nil
+}
+
+func (r *MenuRepository) RetrieveAll() ([]models.MenuItem, error) {
+	const key = "menu_items:all"
+	if v, ok := r.cache.Get(key); ok {
+		return v.([]models.MenuItem), nil
+	}
+
+	items, err := r.MenuRepository.RetrieveAll()
+	if err != nil {
+		return items, err
+	}
+
+	r.cache.Set(key, items)
+	return items, nil
+}
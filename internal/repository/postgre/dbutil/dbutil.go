@@ -0,0 +1,138 @@
+// Package dbutil provides generic helpers shared by every Postgres
+// repository: scanning rows into a type, and paginating a filtered query.
+// It also centralises the sql.ErrNoRows / *pq.Error -> domain error mapping
+// that used to be duplicated in each repository method.
+package dbutil
+
+import (
+	"database/sql"
+	"errors"
+
+	"frappuccino/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// RowScanner is satisfied by both *sql.Row and *sql.Rows.
+type RowScanner interface {
+	Scan(dest ...any) error
+}
+
+// Conn is the subset of dbConn that reading helpers need; it is satisfied by
+// *sql.DB, *sql.Tx, and every repository package's local dbConn.
+type Conn interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// MapError translates driver-level errors into the package's domain errors.
+func MapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrNoRecord
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return models.ErrDuplicateInventory
+		case "23503":
+			return models.ErrNoRecord
+		case "23514":
+			return models.ErrNegativeQuantity
+		case "22P02":
+			return models.ErrInvalidEnumTypeInventory
+		}
+	}
+
+	return err
+}
+
+// QueryOne runs query, scans a single row into a T via scan, and maps
+// sql.ErrNoRows/*pq.Error to the package's domain errors.
+func QueryOne[T any](conn Conn, scan func(RowScanner, *T) error, query string, args ...any) (T, error) {
+	var out T
+	if err := scan(conn.QueryRow(query, args...), &out); err != nil {
+		return out, MapError(err)
+	}
+	return out, nil
+}
+
+// QueryAll runs query and scans every row into a T via scan.
+func QueryAll[T any](conn Conn, scan func(RowScanner, *T) error, query string, args ...any) ([]T, error) {
+	rows, err := conn.Query(query, args...)
+	if err != nil {
+		return nil, MapError(err)
+	}
+	defer rows.Close()
+
+	var out []T
+	for rows.Next() {
+		var item T
+		if err := scan(rows, &item); err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// clampPaging clamps a zero or negative opts.Page/PageSize (e.g. a
+// models.ListOptions{} built without models.DefaultListOptions) to 1 rather
+// than dividing by zero or producing a negative offset.
+func clampPaging(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	return page, pageSize
+}
+
+// Page is a paginated, already-scanned result set.
+type Page[T any] struct {
+	Items      []T
+	Total      int
+	Page       int
+	PageSize   int
+	TotalPages int
+}
+
+// Paginate counts the rows matched by countSQL, then runs selectSQL (which
+// must accept args followed by a page-size and an offset placeholder, in
+// that order) and scans every row into a T via scan. A zero-value
+// opts.Page/PageSize (e.g. a models.ListOptions{} built without
+// models.DefaultListOptions) is clamped to 1 rather than dividing by zero.
+func Paginate[T any](conn Conn, opts models.ListOptions, countSQL, selectSQL string, scan func(RowScanner, *T) error, args ...any) (Page[T], error) {
+	page, pageSize := clampPaging(opts.Page, opts.PageSize)
+
+	var total int
+	if err := conn.QueryRow(countSQL, args...).Scan(&total); err != nil {
+		return Page[T]{}, MapError(err)
+	}
+	totalPages := (total + pageSize - 1) / pageSize
+
+	offset := (page - 1) * pageSize
+	pagedArgs := append(append([]any{}, args...), pageSize, offset)
+
+	items, err := QueryAll(conn, scan, selectSQL, pagedArgs...)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	return Page[T]{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
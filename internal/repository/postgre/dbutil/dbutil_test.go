@@ -0,0 +1,26 @@
+package dbutil
+
+import "testing"
+
+func TestClampPaging(t *testing.T) {
+	tests := []struct {
+		name             string
+		page, pageSize   int
+		wantP, wantPSize int
+	}{
+		{"zero value clamps both to 1", 0, 0, 1, 1},
+		{"negative page clamps to 1", -5, 20, 1, 20},
+		{"negative page size clamps to 1", 1, -20, 1, 1},
+		{"valid values pass through unchanged", 3, 20, 3, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotP, gotPSize := clampPaging(tt.page, tt.pageSize)
+			if gotP != tt.wantP || gotPSize != tt.wantPSize {
+				t.Errorf("clampPaging(%d, %d) = (%d, %d), want (%d, %d)",
+					tt.page, tt.pageSize, gotP, gotPSize, tt.wantP, tt.wantPSize)
+			}
+		})
+	}
+}
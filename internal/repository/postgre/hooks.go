@@ -0,0 +1,109 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+
+	"frappuccino/internal/repository"
+)
+
+// hookRegistry accumulates repository.Hooks per repository.HookPoint and
+// runs them in registration order. inventoryRepositoryPostgres and
+// menuRepositoryPostgres hold one via a pointer shared with every other
+// instance built by the same dbFactory, so a hook registered through one
+// Inventory()/Menu() call fires whether the mutation that trips it runs
+// standalone or inside a TransactionContext built from the same factory.
+type hookRegistry struct {
+	hooks      map[repository.HookPoint][]repository.Hook
+	registered map[repository.HookPoint]map[uintptr]bool
+}
+
+func newHookRegistry() *hookRegistry {
+	return &hookRegistry{
+		hooks:      make(map[repository.HookPoint][]repository.Hook),
+		registered: make(map[repository.HookPoint]map[uintptr]bool),
+	}
+}
+
+// RegisterHook appends hook to run at point, unless the same hook function
+// is already registered there. A hookRegistry is shared by every repository
+// a RepositoryFactory ever hands out (see the type comment above), so
+// passing one factory to NewInventoryService/NewMenuService more than once
+// would otherwise re-register WithCache's built-in cache-invalidation hook
+// (and any WithHook option) on every call, making it run multiple times per
+// mutation. Identity is compared by the hook's code pointer via
+// reflect.Value.Pointer, which is stable across calls that build the same
+// hook closure (e.g. hook.NewCacheInvalidationHook) from different
+// repository.RegisterHook callers.
+func (r *hookRegistry) RegisterHook(point repository.HookPoint, hook repository.Hook) {
+	ptr := reflect.ValueOf(hook).Pointer()
+
+	seen, ok := r.registered[point]
+	if !ok {
+		seen = make(map[uintptr]bool)
+		r.registered[point] = seen
+	}
+	if seen[ptr] {
+		return
+	}
+	seen[ptr] = true
+
+	r.hooks[point] = append(r.hooks[point], hook)
+}
+
+// has reports whether any hook is registered for point, so callers can skip
+// building a Before snapshot (which costs an extra read) when nothing is
+// listening.
+func (r *hookRegistry) has(point repository.HookPoint) bool {
+	return len(r.hooks[point]) > 0
+}
+
+// run executes every hook registered for point, in order, stopping at (and
+// returning) the first error so a Before* hook can abort the operation.
+func (r *hookRegistry) run(ctx context.Context, point repository.HookPoint, op repository.HookOp) error {
+	for _, hook := range r.hooks[point] {
+		if err := hook(ctx, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hookRegistries bundles the per-entity registries a dbFactory hands to
+// every repository it constructs.
+type hookRegistries struct {
+	inventory *hookRegistry
+	menu      *hookRegistry
+}
+
+func newHookRegistries() *hookRegistries {
+	return &hookRegistries{inventory: newHookRegistry(), menu: newHookRegistry()}
+}
+
+// runInMutationTx runs fn, which issues a single write statement against
+// conn, so that an AfterInsert/AfterUpdate/AfterDelete hook error can still
+// abort the write. If conn is already a *sql.Tx (the repository was built
+// inside a TransactionContext), fn joins that caller-managed transaction
+// and its error is simply propagated for the caller to roll back. If conn
+// is the pool itself, runInMutationTx opens an implicit transaction around
+// fn so a hook returning an error rolls the write back before it's ever
+// visible.
+func runInMutationTx(conn dbConn, fn func(dbConn) error) error {
+	db, ok := conn.(*sql.DB)
+	if !ok {
+		return fn(conn)
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
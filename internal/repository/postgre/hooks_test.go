@@ -0,0 +1,52 @@
+package postgre
+
+import (
+	"context"
+	"testing"
+
+	"frappuccino/internal/repository"
+)
+
+func TestHookRegistry_RegisterHookDedupesSameHookAtSamePoint(t *testing.T) {
+	r := newHookRegistry()
+
+	calls := 0
+	hook := func(ctx context.Context, op repository.HookOp) error {
+		calls++
+		return nil
+	}
+
+	r.RegisterHook(repository.AfterUpdate, hook)
+	r.RegisterHook(repository.AfterUpdate, hook)
+
+	if err := r.run(context.Background(), repository.AfterUpdate, repository.HookOp{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("hook ran %d times, want 1 (duplicate registration should be ignored)", calls)
+	}
+}
+
+func TestHookRegistry_RegisterHookAllowsDistinctHooksAtSamePoint(t *testing.T) {
+	r := newHookRegistry()
+
+	var calls []string
+	first := func(ctx context.Context, op repository.HookOp) error {
+		calls = append(calls, "first")
+		return nil
+	}
+	second := func(ctx context.Context, op repository.HookOp) error {
+		calls = append(calls, "second")
+		return nil
+	}
+
+	r.RegisterHook(repository.AfterUpdate, first)
+	r.RegisterHook(repository.AfterUpdate, second)
+
+	if err := r.run(context.Background(), repository.AfterUpdate, repository.HookOp{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("ran %d hooks, want 2 distinct hooks to both run", len(calls))
+	}
+}
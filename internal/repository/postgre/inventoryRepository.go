@@ -1,173 +1,309 @@
 package postgre
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"log/slog"
 
 	"frappuccino/internal/models"
+	"frappuccino/internal/repository"
+	"frappuccino/internal/repository/postgre/dbutil"
 
 	"github.com/lib/pq"
 )
 
+// dbConn is satisfied by both *sql.DB and *sql.Tx, so a repository built
+// around it works unmodified whether it runs standalone or inside a
+// TransactionContext.
+type dbConn interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
 type inventoryRepositoryPostgres struct {
-	pq     *sql.DB
+	pq     dbConn
 	logger *slog.Logger
+	hooks  *hookRegistry
 }
 
 func NewInventoryRepositoryWithPostgres(db *sql.DB, logger *slog.Logger) *inventoryRepositoryPostgres {
 	return &inventoryRepositoryPostgres{
 		pq:     db,
 		logger: logger,
+		hooks:  newHookRegistry(),
+	}
+}
+
+// newInventoryRepository builds an inventoryRepositoryPostgres bound to any
+// dbConn (typically a *sql.Tx handed out by a TransactionContext), sharing
+// hooks with every other repository built from the same factory.
+func newInventoryRepository(conn dbConn, logger *slog.Logger, hooks *hookRegistry) *inventoryRepositoryPostgres {
+	return &inventoryRepositoryPostgres{
+		pq:     conn,
+		logger: logger,
+		hooks:  hooks,
 	}
 }
 
+func (m *inventoryRepositoryPostgres) RegisterHook(point repository.HookPoint, hook repository.Hook) {
+	m.hooks.RegisterHook(point, hook)
+}
+
+func scanInventory(row dbutil.RowScanner, inv *models.Inventory) error {
+	return row.Scan(&inv.ID, &inv.Name, &inv.Quantity, &inv.Unit, pq.Array(&inv.Categories))
+}
+
+func scanLeftOver(row dbutil.RowScanner, inv *models.InventoryLeftOverItem) error {
+	return row.Scan(&inv.Name, &inv.Quantity)
+}
+
 func (m *inventoryRepositoryPostgres) Insert(name, unit string, quantity int, categories []string) error {
-	_, err := m.pq.Exec(
-		"INSERT INTO inventory (name, quantity, unit, categories) VALUES ($1, $2, $3, $4)",
-		name, quantity, unit, pq.Array(categories),
-	)
-	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code {
-			case "23505":
-				return models.ErrDuplicateInventory
-			case "23514":
-				return models.ErrNegativeQuantity
-			case "22P02":
-				return models.ErrInvalidEnumTypeInventory
-			}
-		}
+	ctx := context.Background()
+	after := models.Inventory{Name: name, Unit: unit, Quantity: quantity, Categories: categories}
+	op := repository.HookOp{Table: "inventory", After: after}
+
+	if err := m.hooks.run(ctx, repository.BeforeInsert, op); err != nil {
 		return err
 	}
 
-	return nil
+	return runInMutationTx(m.pq, func(conn dbConn) error {
+		var id int
+		err := conn.QueryRow(
+			"INSERT INTO inventory (name, quantity, unit, categories) VALUES ($1, $2, $3, $4) RETURNING id",
+			name, quantity, unit, pq.Array(categories),
+		).Scan(&id)
+		if err != nil {
+			return dbutil.MapError(err)
+		}
+
+		after.ID = id
+		op.ID, op.After = id, after
+
+		return m.hooks.run(ctx, repository.AfterInsert, op)
+	})
 }
 
 func (m *inventoryRepositoryPostgres) RetrieveByID(id int) (models.Inventory, error) {
-	var inventory models.Inventory
-	err := m.pq.QueryRow("SELECT * FROM inventory WHERE id = $1", id).Scan(
-		&inventory.ID,
-		&inventory.Name,
-		&inventory.Quantity,
-		&inventory.Unit,
-		pq.Array(&inventory.Categories),
-	)
+	return dbutil.QueryOne(m.pq, scanInventory, "SELECT id, name, quantity, unit, categories FROM inventory WHERE id = $1", id)
+}
+
+func (m *inventoryRepositoryPostgres) RetrieveAll(opts models.ListOptions) ([]models.Inventory, int, int, error) {
+	filter, err := buildInventoryFilter(opts)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return models.Inventory{}, models.ErrNoRecord
-		}
-		return models.Inventory{}, err
+		return nil, 0, 0, err
 	}
 
-	return inventory, nil
-}
+	countSQL := fmt.Sprintf("SELECT COUNT(*) FROM inventory %s", filter.where)
+	selectSQL := fmt.Sprintf(
+		"SELECT id, name, quantity, unit, categories FROM inventory %s %s LIMIT $%d OFFSET $%d",
+		filter.where, filter.orderBy, len(filter.args)+1, len(filter.args)+2,
+	)
 
-func (m *inventoryRepositoryPostgres) RetrieveAll() ([]models.Inventory, error) {
-	rows, err := m.pq.Query("SELECT * FROM inventory")
+	page, err := dbutil.Paginate(m.pq, opts, countSQL, selectSQL, scanInventory, filter.args...)
 	if err != nil {
-		m.logger.Error("Failed to execute Query", "error", err)
-		return nil, err
+		return nil, 0, 0, err
 	}
-	defer rows.Close()
 
-	var InventoryAll []models.Inventory
-	for rows.Next() {
-		var inventory models.Inventory
+	return page.Items, page.TotalPages, page.Total, nil
+}
 
-		err = rows.Scan(
-			&inventory.ID,
-			&inventory.Name,
-			&inventory.Quantity,
-			&inventory.Unit,
-			pq.Array(&inventory.Categories),
-		)
+// Update edits item metadata only. Quantity changes go through Adjust so
+// every movement is captured in the inventory_transactions ledger.
+func (m *inventoryRepositoryPostgres) Update(id int, name, unit string, categories []string) error {
+	ctx := context.Background()
+	op := repository.HookOp{
+		Table: "inventory",
+		ID:    id,
+		After: models.Inventory{ID: id, Name: name, Unit: unit, Categories: categories},
+	}
+
+	if m.hooks.has(repository.BeforeUpdate) || m.hooks.has(repository.AfterUpdate) {
+		before, err := m.RetrieveByID(id)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		op.Before = before
 
-		InventoryAll = append(InventoryAll, inventory)
+		// Update leaves quantity untouched, so the after state keeps it.
+		after := op.After.(models.Inventory)
+		after.Quantity = before.Quantity
+		op.After = after
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
+	if err := m.hooks.run(ctx, repository.BeforeUpdate, op); err != nil {
+		return err
 	}
 
-	return InventoryAll, err
-}
+	return runInMutationTx(m.pq, func(conn dbConn) error {
+		result, err := conn.Exec(
+			"UPDATE inventory SET name=$1, unit=$2, categories=$3 WHERE id=$4",
+			name, unit, pq.Array(categories), id,
+		)
+		if err != nil {
+			return dbutil.MapError(err)
+		}
 
-func (m *inventoryRepositoryPostgres) Update(id int, name, unit string, quantity int, categories []string) error {
-	result, err := m.pq.Exec(
-		"UPDATE inventory SET name=$1, unit=$2, quantity=$3, categories=$4 WHERE id=$5",
-		name, unit, quantity, pq.Array(categories), id,
-	)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return models.ErrNoRecord
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
 		}
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code {
-			case "23505":
-				return models.ErrDuplicateInventory
-			case "23514":
-				return models.ErrNegativeQuantity
-			case "22P02":
-				return models.ErrInvalidEnumTypeInventory
-			}
+		if rowsAffected == 0 {
+			return models.ErrNoRecord
 		}
 
+		return m.hooks.run(ctx, repository.AfterUpdate, op)
+	})
+}
+
+// Adjust applies delta to the item's quantity and appends the movement to
+// the inventory_transactions ledger, as a single DB transaction. When m is
+// already bound to a *sql.Tx (e.g. via postgre.WithTx), the ledger insert
+// and quantity update join that caller-managed transaction; when m is bound
+// to the pool directly, Adjust opens and manages its own transaction so the
+// two writes still commit or roll back together.
+func (m *inventoryRepositoryPostgres) Adjust(id int, delta int, reason models.InventoryTransactionReason, orderID *int, actor string) error {
+	db, ok := m.pq.(*sql.DB)
+	if !ok {
+		return m.adjust(m.pq, id, delta, reason, orderID, actor)
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if rowsAffected == 0 {
-		return models.ErrNoRecord
+	if err := m.adjust(tx, id, delta, reason, orderID, actor); err != nil {
+		tx.Rollback()
+		return err
 	}
 
-	return err
+	return tx.Commit()
 }
 
-func (m *inventoryRepositoryPostgres) Delete(id int) error {
-	result, err := m.pq.Exec("DELETE FROM inventory WHERE id=$1", id)
-	if err != nil {
+func (m *inventoryRepositoryPostgres) adjust(conn dbConn, id int, delta int, reason models.InventoryTransactionReason, orderID *int, actor string) error {
+	ctx := context.Background()
+	op := repository.HookOp{Table: "inventory", ID: id}
+
+	if m.hooks.has(repository.BeforeUpdate) || m.hooks.has(repository.AfterUpdate) {
+		var before models.Inventory
+		if err := scanInventory(conn.QueryRow("SELECT id, name, quantity, unit, categories FROM inventory WHERE id = $1", id), &before); err != nil {
+			return dbutil.MapError(err)
+		}
+		op.Before = before
+
+		// The expected after state, replaced below by a re-read once the
+		// UPDATE has run.
+		after := before
+		after.Quantity += delta
+		op.After = after
+	}
+
+	if err := m.hooks.run(ctx, repository.BeforeUpdate, op); err != nil {
 		return err
 	}
 
+	_, err := conn.Exec(
+		"INSERT INTO inventory_transactions (inventory_id, delta, reason, order_id, actor) VALUES ($1, $2, $3, $4, $5)",
+		id, delta, reason, orderID, actor,
+	)
+	if err != nil {
+		return dbutil.MapError(err)
+	}
+
+	result, err := conn.Exec(
+		"UPDATE inventory SET quantity = quantity + $1 WHERE id = $2 AND quantity + $1 >= 0",
+		delta, id,
+	)
+	if err != nil {
+		return dbutil.MapError(err)
+	}
+
 	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
 	if rowsAffected == 0 {
-		return models.ErrNoRecord
+		// The UPDATE's WHERE clause can return zero rows either because id
+		// doesn't exist or because the adjustment would take the quantity
+		// negative; tell those apart so a bad id isn't misreported as a
+		// negative-quantity error.
+		var exists bool
+		if err := conn.QueryRow("SELECT EXISTS (SELECT 1 FROM inventory WHERE id = $1)", id).Scan(&exists); err != nil {
+			return dbutil.MapError(err)
+		}
+		if !exists {
+			return models.ErrNoRecord
+		}
+		return models.ErrNegativeQuantity
 	}
 
-	return err
+	if m.hooks.has(repository.AfterUpdate) {
+		var after models.Inventory
+		if err := scanInventory(conn.QueryRow("SELECT id, name, quantity, unit, categories FROM inventory WHERE id = $1", id), &after); err != nil {
+			return dbutil.MapError(err)
+		}
+		op.After = after
+	}
+
+	// Quantity changed: run the same AfterUpdate hook point Update fires on
+	// metadata edits, so WithCache's invalidation hook evicts "inventory:id"
+	// and "inventory:all" here too. Without this, a read-through cache never
+	// learns that Adjust moved the quantity.
+	return m.hooks.run(ctx, repository.AfterUpdate, op)
 }
 
-func (m *inventoryRepositoryPostgres) GetLeftOvers(sortColumn string, page, pageSize int) ([]models.InventoryLeftOverItem, int, error) {
-	offset := (page - 1) * pageSize
+func (m *inventoryRepositoryPostgres) Delete(id int) error {
+	ctx := context.Background()
+	op := repository.HookOp{Table: "inventory", ID: id}
 
-	var totalItems int
-	err := m.pq.QueryRow("SELECT COUNT(*) FROM inventory").Scan(&totalItems)
-	if err != nil {
-		return nil, 0, err
+	if m.hooks.has(repository.BeforeDelete) || m.hooks.has(repository.AfterDelete) {
+		before, err := m.RetrieveByID(id)
+		if err != nil {
+			return err
+		}
+		op.Before = before
 	}
-	totalPages := (totalItems + pageSize - 1) / pageSize
 
-	query := fmt.Sprintf(`SELECT name, quantity FROM inventory ORDER BY %s DESC LIMIT $1 OFFSET $2`, sortColumn)
-	rows, err := m.pq.Query(query, pageSize, offset)
-	if err != nil {
-		m.logger.Error("failed to execute query", "error", err.Error())
-		return nil, 0, err
+	if err := m.hooks.run(ctx, repository.BeforeDelete, op); err != nil {
+		return err
 	}
-	defer rows.Close()
 
-	var leftovers []models.InventoryLeftOverItem
-	for rows.Next() {
-		var inv models.InventoryLeftOverItem
-		if err := rows.Scan(&inv.Name, &inv.Quantity); err != nil {
-			return nil, 0, err
+	return runInMutationTx(m.pq, func(conn dbConn) error {
+		result, err := conn.Exec("DELETE FROM inventory WHERE id=$1", id)
+		if err != nil {
+			return dbutil.MapError(err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
 		}
-		leftovers = append(leftovers, inv)
+		if rowsAffected == 0 {
+			return models.ErrNoRecord
+		}
+
+		return m.hooks.run(ctx, repository.AfterDelete, op)
+	})
+}
+
+func (m *inventoryRepositoryPostgres) GetLeftOvers(opts models.ListOptions) ([]models.InventoryLeftOverItem, int, int, error) {
+	filter, err := buildInventoryFilter(opts)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	countSQL := fmt.Sprintf("SELECT COUNT(*) FROM inventory %s", filter.where)
+	selectSQL := fmt.Sprintf(
+		"SELECT name, quantity FROM inventory %s %s LIMIT $%d OFFSET $%d",
+		filter.where, filter.orderBy, len(filter.args)+1, len(filter.args)+2,
+	)
+
+	page, err := dbutil.Paginate(m.pq, opts, countSQL, selectSQL, scanLeftOver, filter.args...)
+	if err != nil {
+		return nil, 0, 0, err
 	}
 
-	return leftovers, totalPages, nil
+	return page.Items, page.TotalPages, page.Total, nil
 }
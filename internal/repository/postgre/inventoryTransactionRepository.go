@@ -0,0 +1,55 @@
+package postgre
+
+import (
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"frappuccino/internal/models"
+	"frappuccino/internal/repository/postgre/dbutil"
+)
+
+type inventoryTransactionRepositoryPostgres struct {
+	pq     dbConn
+	logger *slog.Logger
+}
+
+func NewInventoryTransactionRepositoryPostgres(db *sql.DB, logger *slog.Logger) *inventoryTransactionRepositoryPostgres {
+	return &inventoryTransactionRepositoryPostgres{
+		pq:     db,
+		logger: logger,
+	}
+}
+
+func newInventoryTransactionRepository(conn dbConn, logger *slog.Logger) *inventoryTransactionRepositoryPostgres {
+	return &inventoryTransactionRepositoryPostgres{
+		pq:     conn,
+		logger: logger,
+	}
+}
+
+func scanInventoryTransaction(row dbutil.RowScanner, t *models.InventoryTransaction) error {
+	return row.Scan(&t.ID, &t.InventoryID, &t.Delta, &t.Reason, &t.OrderID, &t.Actor, &t.CreatedAt)
+}
+
+// RetrieveHistory returns the ledger rows for inventoryID, newest first,
+// optionally narrowed by [from, to] and reason. It relies on the
+// (inventory_id, created_at) index on inventory_transactions.
+func (r *inventoryTransactionRepositoryPostgres) RetrieveHistory(inventoryID int, from, to *time.Time, reason *models.InventoryTransactionReason) ([]models.InventoryTransaction, error) {
+	var reasonArg any
+	if reason != nil {
+		reasonArg = string(*reason)
+	}
+
+	return dbutil.QueryAll(
+		r.pq, scanInventoryTransaction,
+		`SELECT id, inventory_id, delta, reason, order_id, actor, created_at
+		 FROM inventory_transactions
+		 WHERE inventory_id = $1
+		   AND ($2::timestamptz IS NULL OR created_at >= $2)
+		   AND ($3::timestamptz IS NULL OR created_at <= $3)
+		   AND ($4::text IS NULL OR reason = $4)
+		 ORDER BY created_at DESC`,
+		inventoryID, from, to, reasonArg,
+	)
+}
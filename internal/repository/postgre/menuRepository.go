@@ -0,0 +1,150 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"frappuccino/internal/models"
+	"frappuccino/internal/repository"
+	"frappuccino/internal/repository/postgre/dbutil"
+
+	"github.com/lib/pq"
+)
+
+type menuRepositoryPostgres struct {
+	pq     dbConn
+	logger *slog.Logger
+	hooks  *hookRegistry
+}
+
+func NewMenuRepositoryPostgres(db *sql.DB, logger *slog.Logger) *menuRepositoryPostgres {
+	return &menuRepositoryPostgres{
+		pq:     db,
+		logger: logger,
+		hooks:  newHookRegistry(),
+	}
+}
+
+// newMenuRepository builds a menuRepositoryPostgres bound to any dbConn,
+// sharing hooks with every other repository built from the same factory.
+func newMenuRepository(conn dbConn, logger *slog.Logger, hooks *hookRegistry) *menuRepositoryPostgres {
+	return &menuRepositoryPostgres{
+		pq:     conn,
+		logger: logger,
+		hooks:  hooks,
+	}
+}
+
+func (m *menuRepositoryPostgres) RegisterHook(point repository.HookPoint, hook repository.Hook) {
+	m.hooks.RegisterHook(point, hook)
+}
+
+func scanMenuItem(row dbutil.RowScanner, menu *models.MenuItem) error {
+	return row.Scan(&menu.ID, &menu.Name, &menu.Description, &menu.Price, pq.Array(&menu.Categories))
+}
+
+func (m *menuRepositoryPostgres) InsertMenuItem(menu models.MenuItem) error {
+	ctx := context.Background()
+	op := repository.HookOp{Table: "menu_items", After: menu}
+
+	if err := m.hooks.run(ctx, repository.BeforeInsert, op); err != nil {
+		return err
+	}
+
+	return runInMutationTx(m.pq, func(conn dbConn) error {
+		var id int
+		err := conn.QueryRow(
+			"INSERT INTO menu_items (name, description, price, categories) VALUES ($1, $2, $3, $4) RETURNING id",
+			menu.Name, menu.Description, menu.Price, pq.Array(menu.Categories),
+		).Scan(&id)
+		if err != nil {
+			return dbutil.MapError(err)
+		}
+
+		menu.ID = id
+		op.ID, op.After = id, menu
+
+		return m.hooks.run(ctx, repository.AfterInsert, op)
+	})
+}
+
+func (m *menuRepositoryPostgres) RetrieveByID(id int) (models.MenuItem, error) {
+	return dbutil.QueryOne(m.pq, scanMenuItem, "SELECT id, name, description, price, categories FROM menu_items WHERE id = $1", id)
+}
+
+func (m *menuRepositoryPostgres) RetrieveAll() ([]models.MenuItem, error) {
+	return dbutil.QueryAll(m.pq, scanMenuItem, "SELECT id, name, description, price, categories FROM menu_items")
+}
+
+func (m *menuRepositoryPostgres) UpdateMenuItem(id int, menu models.MenuItem) error {
+	ctx := context.Background()
+	menu.ID = id
+	op := repository.HookOp{Table: "menu_items", ID: id, After: menu}
+
+	if m.hooks.has(repository.BeforeUpdate) || m.hooks.has(repository.AfterUpdate) {
+		before, err := m.RetrieveByID(id)
+		if err != nil {
+			return err
+		}
+		op.Before = before
+	}
+
+	if err := m.hooks.run(ctx, repository.BeforeUpdate, op); err != nil {
+		return err
+	}
+
+	return runInMutationTx(m.pq, func(conn dbConn) error {
+		result, err := conn.Exec(
+			"UPDATE menu_items SET name=$1, description=$2, price=$3, categories=$4 WHERE id=$5",
+			menu.Name, menu.Description, menu.Price, pq.Array(menu.Categories), id,
+		)
+		if err != nil {
+			return dbutil.MapError(err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return models.ErrNoRecord
+		}
+
+		return m.hooks.run(ctx, repository.AfterUpdate, op)
+	})
+}
+
+func (m *menuRepositoryPostgres) Delete(id int) error {
+	ctx := context.Background()
+	op := repository.HookOp{Table: "menu_items", ID: id}
+
+	if m.hooks.has(repository.BeforeDelete) || m.hooks.has(repository.AfterDelete) {
+		before, err := m.RetrieveByID(id)
+		if err != nil {
+			return err
+		}
+		op.Before = before
+	}
+
+	if err := m.hooks.run(ctx, repository.BeforeDelete, op); err != nil {
+		return err
+	}
+
+	return runInMutationTx(m.pq, func(conn dbConn) error {
+		result, err := conn.Exec("DELETE FROM menu_items WHERE id=$1", id)
+		if err != nil {
+			return dbutil.MapError(err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return models.ErrNoRecord
+		}
+
+		return m.hooks.run(ctx, repository.AfterDelete, op)
+	})
+}
@@ -0,0 +1,79 @@
+package postgre
+
+import (
+	"database/sql"
+	"log/slog"
+
+	"frappuccino/internal/models"
+	"frappuccino/internal/repository/postgre/dbutil"
+)
+
+type orderRepositoryPostgres struct {
+	pq     dbConn
+	logger *slog.Logger
+}
+
+func NewOrderRepositoryPostgres(db *sql.DB, logger *slog.Logger) *orderRepositoryPostgres {
+	return &orderRepositoryPostgres{
+		pq:     db,
+		logger: logger,
+	}
+}
+
+func newOrderRepository(conn dbConn, logger *slog.Logger) *orderRepositoryPostgres {
+	return &orderRepositoryPostgres{
+		pq:     conn,
+		logger: logger,
+	}
+}
+
+func scanOrder(row dbutil.RowScanner, order *models.Order) error {
+	return row.Scan(&order.ID, &order.CustomerID, &order.Status)
+}
+
+func (o *orderRepositoryPostgres) Insert(order models.Order) error {
+	_, err := o.pq.Exec("INSERT INTO orders (customer_id, status) VALUES ($1, $2)", order.CustomerID, order.Status)
+	return dbutil.MapError(err)
+}
+
+func (o *orderRepositoryPostgres) RetrieveByID(id int) (models.Order, error) {
+	return dbutil.QueryOne(o.pq, scanOrder, "SELECT id, customer_id, status FROM orders WHERE id = $1", id)
+}
+
+func (o *orderRepositoryPostgres) RetrieveAll() ([]models.Order, error) {
+	return dbutil.QueryAll(o.pq, scanOrder, "SELECT id, customer_id, status FROM orders")
+}
+
+func (o *orderRepositoryPostgres) Update(id int, order models.Order) error {
+	result, err := o.pq.Exec("UPDATE orders SET customer_id=$1, status=$2 WHERE id=$3", order.CustomerID, order.Status, id)
+	if err != nil {
+		return dbutil.MapError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return models.ErrNoRecord
+	}
+
+	return nil
+}
+
+func (o *orderRepositoryPostgres) Delete(id int) error {
+	result, err := o.pq.Exec("DELETE FROM orders WHERE id=$1", id)
+	if err != nil {
+		return dbutil.MapError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return models.ErrNoRecord
+	}
+
+	return nil
+}
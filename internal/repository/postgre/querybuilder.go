@@ -0,0 +1,69 @@
+package postgre
+
+import (
+	"fmt"
+	"strings"
+
+	"frappuccino/internal/models"
+)
+
+// inventorySortColumns whitelists the columns ListOptions.Sort may reference,
+// so a caller-supplied sort column can never be interpolated into SQL.
+var inventorySortColumns = map[models.SortColumn]string{
+	models.SortByName:     "name",
+	models.SortByQuantity: "quantity",
+	models.SortByUnit:     "unit",
+}
+
+// inventoryFilter is a parameterised WHERE/ORDER BY clause built from
+// ListOptions, along with the args it references.
+type inventoryFilter struct {
+	where   string
+	orderBy string
+	args    []any
+}
+
+// buildInventoryFilter validates opts and turns it into a parameterised
+// filter. It never interpolates caller-controlled strings into the SQL text.
+func buildInventoryFilter(opts models.ListOptions) (inventoryFilter, error) {
+	column, ok := inventorySortColumns[opts.Sort]
+	if !ok {
+		return inventoryFilter{}, models.ErrInvalidSortColumn
+	}
+
+	dir := "ASC"
+	if opts.Dir == models.SortDesc {
+		dir = "DESC"
+	}
+
+	var conditions []string
+	var args []any
+
+	if opts.Category != "" {
+		args = append(args, opts.Category)
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(categories)", len(args)))
+	}
+	if opts.MinQty != nil {
+		args = append(args, *opts.MinQty)
+		conditions = append(conditions, fmt.Sprintf("quantity >= $%d", len(args)))
+	}
+	if opts.MaxQty != nil {
+		args = append(args, *opts.MaxQty)
+		conditions = append(conditions, fmt.Sprintf("quantity <= $%d", len(args)))
+	}
+	if opts.NameLike != "" {
+		args = append(args, "%"+opts.NameLike+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return inventoryFilter{
+		where:   where,
+		orderBy: fmt.Sprintf("ORDER BY %s %s", column, dir),
+		args:    args,
+	}, nil
+}
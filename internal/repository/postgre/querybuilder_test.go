@@ -0,0 +1,71 @@
+package postgre
+
+import (
+	"testing"
+
+	"frappuccino/internal/models"
+)
+
+func TestBuildInventoryFilter_RejectsUnknownSortColumn(t *testing.T) {
+	_, err := buildInventoryFilter(models.ListOptions{Sort: models.SortColumn("quantity; DROP TABLE inventory")})
+	if err != models.ErrInvalidSortColumn {
+		t.Fatalf("got error %v, want %v", err, models.ErrInvalidSortColumn)
+	}
+}
+
+func TestBuildInventoryFilter(t *testing.T) {
+	minQty := 5
+
+	tests := []struct {
+		name        string
+		opts        models.ListOptions
+		wantOrderBy string
+		wantWhere   string
+		wantArgs    []any
+	}{
+		{
+			name:        "sorts by whitelisted column ascending",
+			opts:        models.ListOptions{Sort: models.SortByName, Dir: models.SortAsc},
+			wantOrderBy: "ORDER BY name ASC",
+			wantWhere:   "",
+		},
+		{
+			name:        "sorts descending",
+			opts:        models.ListOptions{Sort: models.SortByQuantity, Dir: models.SortDesc},
+			wantOrderBy: "ORDER BY quantity DESC",
+			wantWhere:   "",
+		},
+		{
+			name:        "filters by category and min quantity",
+			opts:        models.ListOptions{Sort: models.SortByUnit, Dir: models.SortAsc, Category: "dairy", MinQty: &minQty},
+			wantOrderBy: "ORDER BY unit ASC",
+			wantWhere:   "WHERE $1 = ANY(categories) AND quantity >= $2",
+			wantArgs:    []any{"dairy", minQty},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := buildInventoryFilter(tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if filter.orderBy != tt.wantOrderBy {
+				t.Errorf("orderBy = %q, want %q", filter.orderBy, tt.wantOrderBy)
+			}
+			if filter.where != tt.wantWhere {
+				t.Errorf("where = %q, want %q", filter.where, tt.wantWhere)
+			}
+			if len(tt.wantArgs) > 0 {
+				if len(filter.args) != len(tt.wantArgs) {
+					t.Fatalf("args = %v, want %v", filter.args, tt.wantArgs)
+				}
+				for i, want := range tt.wantArgs {
+					if filter.args[i] != want {
+						t.Errorf("args[%d] = %v, want %v", i, filter.args[i], want)
+					}
+				}
+			}
+		})
+	}
+}
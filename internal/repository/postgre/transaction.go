@@ -0,0 +1,170 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"frappuccino/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+// serializationFailure is the SQLSTATE Postgres returns when a SERIALIZABLE
+// transaction must be retried because it conflicted with a concurrent one.
+const serializationFailure = "40001"
+
+// TransactionContext wraps a single *sql.Tx and hands out repositories bound
+// to it, so every mutation performed through it commits or rolls back as one
+// unit.
+type TransactionContext struct {
+	tx     *sql.Tx
+	logger *slog.Logger
+	hooks  *hookRegistries
+}
+
+// BeginTransaction starts a standalone transaction with no hooks registered.
+// Prefer dbFactory.WithTx/dbFactory.BeginTransaction when repositories
+// obtained from a RepositoryFactory need their hooks to keep firing inside
+// the transaction.
+func BeginTransaction(db *sql.DB, logger *slog.Logger) (*TransactionContext, error) {
+	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionContext{tx: tx, logger: logger, hooks: newHookRegistries()}, nil
+}
+
+func (t *TransactionContext) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *TransactionContext) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func (t *TransactionContext) Inventory() repository.InventoryRepository {
+	return newInventoryRepository(t.tx, t.logger, t.hooks.inventory)
+}
+
+func (t *TransactionContext) InventoryTransaction() repository.InventoryTransactionRepository {
+	return newInventoryTransactionRepository(t.tx, t.logger)
+}
+
+func (t *TransactionContext) Menu() repository.MenuRepository {
+	return newMenuRepository(t.tx, t.logger, t.hooks.menu)
+}
+
+func (t *TransactionContext) Order() repository.OrderRepository {
+	return newOrderRepository(t.tx, t.logger)
+}
+
+// dbFactory is the RepositoryFactory used outside of any transaction, where
+// every repository talks to the pool directly.
+type dbFactory struct {
+	db     *sql.DB
+	logger *slog.Logger
+	hooks  *hookRegistries
+}
+
+func NewRepositoryFactory(db *sql.DB, logger *slog.Logger) repository.RepositoryFactory {
+	return &dbFactory{db: db, logger: logger, hooks: newHookRegistries()}
+}
+
+func (f *dbFactory) Inventory() repository.InventoryRepository {
+	return newInventoryRepository(f.db, f.logger, f.hooks.inventory)
+}
+
+func (f *dbFactory) InventoryTransaction() repository.InventoryTransactionRepository {
+	return newInventoryTransactionRepository(f.db, f.logger)
+}
+
+func (f *dbFactory) Menu() repository.MenuRepository {
+	return newMenuRepository(f.db, f.logger, f.hooks.menu)
+}
+
+func (f *dbFactory) Order() repository.OrderRepository {
+	return newOrderRepository(f.db, f.logger)
+}
+
+// BeginTransaction starts a transaction whose repositories share f's hook
+// registrations, so a hook registered through f.Inventory().RegisterHook or
+// f.Menu().RegisterHook fires whether the mutation it guards runs standalone
+// or inside the transaction.
+func (f *dbFactory) BeginTransaction() (*TransactionContext, error) {
+	tx, err := f.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionContext{tx: tx, logger: f.logger, hooks: f.hooks}, nil
+}
+
+// WithTx implements repository.RepositoryFactory.
+func (f *dbFactory) WithTx(fn func(repository.RepoSet) error) error {
+	return withTx(f.BeginTransaction, fn)
+}
+
+// maxSerializationRetries bounds how many times WithTx will replay a
+// transaction that failed with a SERIALIZABLE conflict.
+const maxSerializationRetries = 3
+
+// WithTx runs fn inside a SERIALIZABLE transaction on db, with no hooks
+// registered. Prefer a dbFactory's WithTx when repositories obtained from a
+// RepositoryFactory need their hooks to keep firing inside the transaction.
+func WithTx(db *sql.DB, logger *slog.Logger, fn func(repository.RepoSet) error) error {
+	return withTx(func() (*TransactionContext, error) { return BeginTransaction(db, logger) }, fn)
+}
+
+// withTx runs fn inside a SERIALIZABLE transaction obtained from beginTx,
+// retrying the whole transaction if Postgres reports a serialization
+// failure (SQLSTATE 40001). fn's repositories all share the same *sql.Tx, so
+// a non-nil return rolls back every change fn made.
+func withTx(beginTx func() (*TransactionContext, error), fn func(repository.RepoSet) error) error {
+	var err error
+	for attempt := 0; attempt <= maxSerializationRetries; attempt++ {
+		var txCtx *TransactionContext
+		txCtx, err = beginTx()
+		if err != nil {
+			return err
+		}
+
+		err = fn(repository.RepoSet{
+			Inventory:            txCtx.Inventory(),
+			InventoryTransaction: txCtx.InventoryTransaction(),
+			Menu:                 txCtx.Menu(),
+			Order:                txCtx.Order(),
+		})
+		if err != nil {
+			txCtx.Rollback()
+			if isSerializationFailure(err) {
+				time.Sleep(time.Duration(attempt+1) * 10 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+
+		if err = txCtx.Commit(); err != nil {
+			if isSerializationFailure(err) {
+				time.Sleep(time.Duration(attempt+1) * 10 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == serializationFailure
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"frappuccino/internal/models"
+)
+
+// HookPoint identifies a point in a repository's write path where a
+// registered Hook runs.
+type HookPoint int
+
+const (
+	BeforeInsert HookPoint = iota
+	AfterInsert
+	BeforeUpdate
+	AfterUpdate
+	BeforeDelete
+	AfterDelete
+)
+
+// HookOp describes the record a hook is firing for. Before and After hold
+// the record's state as the repository understood it, and are nil where
+// there is no such state (Before on an insert, After on a delete).
+type HookOp struct {
+	Table  string
+	ID     int
+	Before any
+	After  any
+}
+
+// Hook runs at a registered HookPoint. Any hook that returns a non-nil error
+// aborts the write: a Before* hook stops it before it runs, and an After*
+// hook rolls it back (the postgre implementations run the write and its
+// After* hooks inside one transaction, whether or not the caller is already
+// inside a TransactionContext).
+type Hook func(ctx context.Context, op HookOp) error
+
+// InventoryRepository abstracts persistence for inventory items. Implementations
+// must be safe to construct against either a *sql.DB or an in-flight *sql.Tx so
+// that callers can compose them inside a TransactionContext.
+type InventoryRepository interface {
+	Insert(name, unit string, quantity int, categories []string) error
+	RetrieveByID(id int) (models.Inventory, error)
+	// RetrieveAll returns (items, totalPages, totalItems, error) for the given
+	// sort/page/filter options.
+	RetrieveAll(opts models.ListOptions) ([]models.Inventory, int, int, error)
+	// Update edits item metadata only (name/unit/categories). Quantity is
+	// changed exclusively through Adjust, which keeps the ledger consistent.
+	Update(id int, name, unit string, categories []string) error
+	// Adjust applies delta to the item's quantity and records the movement in
+	// the inventory_transactions ledger. The ledger row and quantity update
+	// commit or roll back together: they join the caller's transaction when
+	// the repository is bound to one (see postgre.WithTx), and otherwise run
+	// in a transaction of their own. It returns models.ErrNegativeQuantity if applying
+	// delta would make the quantity negative.
+	Adjust(id int, delta int, reason models.InventoryTransactionReason, orderID *int, actor string) error
+	Delete(id int) error
+	// GetLeftOvers returns (items, totalPages, totalItems, error).
+	GetLeftOvers(opts models.ListOptions) ([]models.InventoryLeftOverItem, int, int, error)
+	// RegisterHook registers hook to run at point on every future
+	// Insert/Update/Delete, including ones already in flight inside a
+	// TransactionContext obtained from the same factory.
+	RegisterHook(point HookPoint, hook Hook)
+}
+
+// InventoryTransactionRepository exposes the append-only stock-movement
+// ledger written by InventoryRepository.Adjust.
+type InventoryTransactionRepository interface {
+	RetrieveHistory(inventoryID int, from, to *time.Time, reason *models.InventoryTransactionReason) ([]models.InventoryTransaction, error)
+}
+
+// MenuRepository abstracts persistence for menu items.
+type MenuRepository interface {
+	InsertMenuItem(menu models.MenuItem) error
+	RetrieveByID(id int) (models.MenuItem, error)
+	RetrieveAll() ([]models.MenuItem, error)
+	UpdateMenuItem(id int, menu models.MenuItem) error
+	Delete(id int) error
+	// RegisterHook registers hook to run at point on every future
+	// InsertMenuItem/UpdateMenuItem/Delete, including ones already in flight
+	// inside a TransactionContext obtained from the same factory.
+	RegisterHook(point HookPoint, hook Hook)
+}
+
+// OrderRepository abstracts persistence for orders.
+type OrderRepository interface {
+	Insert(order models.Order) error
+	RetrieveByID(id int) (models.Order, error)
+	RetrieveAll() ([]models.Order, error)
+	Update(id int, order models.Order) error
+	Delete(id int) error
+}
+
+// RepositoryFactory builds repositories that all share the same underlying
+// connection. The repositories its Inventory/Menu/Order methods return use
+// that connection directly, statement by statement; the RepoSet WithTx hands
+// to fn shares one *sql.Tx, so those repositories participate in the same
+// unit of work.
+type RepositoryFactory interface {
+	Inventory() InventoryRepository
+	InventoryTransaction() InventoryTransactionRepository
+	Menu() MenuRepository
+	Order() OrderRepository
+	// WithTx runs fn inside a transaction whose repositories share this
+	// factory's hook registrations, so a hook registered through
+	// Inventory().RegisterHook or Menu().RegisterHook fires whether the
+	// mutation it guards runs standalone or inside fn.
+	WithTx(fn func(RepoSet) error) error
+}
+
+// RepoSet is the bundle of repositories handed to a WithTx callback.
+type RepoSet struct {
+	Inventory            InventoryRepository
+	InventoryTransaction InventoryTransactionRepository
+	Menu                 MenuRepository
+	Order                OrderRepository
+}
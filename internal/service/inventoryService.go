@@ -0,0 +1,59 @@
+package service
+
+import (
+	"time"
+
+	"frappuccino/internal/models"
+	"frappuccino/internal/repository"
+	"frappuccino/internal/repository/cached"
+)
+
+type inventoryService struct {
+	inventoryRepo   repository.InventoryRepository
+	transactionRepo repository.InventoryTransactionRepository
+}
+
+// NewInventoryService builds an inventoryService whose repository is bound
+// to whatever unit of work factory produces.
+//
+// Build one inventoryService per factory at startup rather than per
+// request: factory's hook registrations are shared by every repository it
+// ever hands out, so calling NewInventoryService again against the same
+// factory re-applies opts' WithHook hooks against that same shared registry
+// (hookRegistry.RegisterHook skips an exact repeat of the built-in
+// cache-invalidation hook, but a distinct WithHook hook passed on a second
+// call still runs again on every subsequent mutation).
+func NewInventoryService(factory repository.RepositoryFactory, opts ...Option) *inventoryService {
+	cfg := newConfig(opts)
+
+	var repo repository.InventoryRepository = factory.Inventory()
+	if cfg.cache != nil {
+		repo = cached.NewInventoryRepository(repo, cfg.cache)
+	}
+	cfg.applyHooks(repo)
+
+	return &inventoryService{
+		inventoryRepo:   repo,
+		transactionRepo: factory.InventoryTransaction(),
+	}
+}
+
+// History returns the stock-movement ledger for an inventory item, optionally
+// narrowed by a time window and reason. RetrieveHistory itself returns an
+// empty slice rather than models.ErrNoRecord for an unknown inventoryID, so
+// History confirms the item exists first to give callers a real not-found.
+func (s *inventoryService) History(inventoryID int, from, to *time.Time, reason *models.InventoryTransactionReason) ([]models.InventoryTransaction, error) {
+	if _, err := s.inventoryRepo.RetrieveByID(inventoryID); err != nil {
+		return nil, err
+	}
+
+	return s.transactionRepo.RetrieveHistory(inventoryID, from, to, reason)
+}
+
+func (s *inventoryService) RetrieveAll(opts models.ListOptions) ([]models.Inventory, int, int, error) {
+	return s.inventoryRepo.RetrieveAll(opts)
+}
+
+func (s *inventoryService) GetLeftOvers(opts models.ListOptions) ([]models.InventoryLeftOverItem, int, int, error) {
+	return s.inventoryRepo.GetLeftOvers(opts)
+}
@@ -1,23 +1,42 @@
 package service
 
 import (
-	"database/sql"
-	"log/slog"
 	"strconv"
 
 	"frappuccino/internal/models"
 	"frappuccino/internal/repository"
-	"frappuccino/internal/repository/postgre"
+	"frappuccino/internal/repository/cached"
 )
 
 type menuService struct {
 	menuRepo repository.MenuRepository
 }
 
-func NewMenuService(db *sql.DB, logger *slog.Logger) *menuService {
-	return &menuService{
-		postgre.NewMenuRepositoryPostgres(db, logger),
+// NewMenuService builds a menuService whose repository is bound to whatever
+// unit of work factory produces. Passing a factory obtained from
+// postgre.NewRepositoryFactory gives the service repositories that talk to
+// the pool directly. A TransactionContext does not implement
+// RepositoryFactory (it has no WithTx), so a menuService is never bound to a
+// caller-managed transaction; factory.WithTx is what hands out repositories
+// sharing one.
+//
+// Build one menuService per factory at startup rather than per request:
+// factory's hook registrations are shared by every repository it ever hands
+// out, so calling NewMenuService again against the same factory re-applies
+// opts' WithHook hooks against that same shared registry (hookRegistry.
+// RegisterHook skips an exact repeat of the built-in cache-invalidation
+// hook, but a distinct WithHook hook passed on a second call still runs
+// again on every subsequent mutation).
+func NewMenuService(factory repository.RepositoryFactory, opts ...Option) *menuService {
+	cfg := newConfig(opts)
+
+	var repo repository.MenuRepository = factory.Menu()
+	if cfg.cache != nil {
+		repo = cached.NewMenuRepository(repo, cfg.cache)
 	}
+	cfg.applyHooks(repo)
+
+	return &menuService{menuRepo: repo}
 }
 
 func (s *menuService) InsertMenu(menu models.MenuItem) (map[string]string, error) {
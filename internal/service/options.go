@@ -0,0 +1,75 @@
+package service
+
+import (
+	"frappuccino/internal/cache"
+	"frappuccino/internal/hook"
+	"frappuccino/internal/repository"
+)
+
+// hookRegistrar is implemented by any repository that supports hook
+// registration (repository.InventoryRepository and repository.MenuRepository
+// both do).
+type hookRegistrar interface {
+	RegisterHook(point repository.HookPoint, hook repository.Hook)
+}
+
+// hookRegistration is a (point, hook) pair deferred until the service knows
+// which repository to register it against.
+type hookRegistration struct {
+	point repository.HookPoint
+	hook  repository.Hook
+}
+
+// config accumulates what NewInventoryService/NewMenuService's Options asked
+// for, before the repository they'll act on has been built.
+type config struct {
+	hooks []hookRegistration
+	cache cache.Cache
+}
+
+// Option configures a service's repository at construction time. Pass
+// options returned by WithHook/WithCache to NewInventoryService/
+// NewMenuService.
+type Option func(*config)
+
+// WithHook returns an Option that registers hook at point against the
+// service's repository, so it fires on every Insert/Update/Delete the
+// service performs from then on.
+func WithHook(point repository.HookPoint, hook repository.Hook) Option {
+	return func(c *config) {
+		c.hooks = append(c.hooks, hookRegistration{point, hook})
+	}
+}
+
+// WithCache returns an Option that fronts the service's repository with a
+// read-through cache backed by c (see internal/repository/cached), and
+// registers the built-in cache-invalidation hook so Insert/Update/Delete
+// keep it consistent with Postgres.
+func WithCache(c cache.Cache) Option {
+	return func(cfg *config) {
+		cfg.cache = c
+	}
+}
+
+// newConfig runs every opt against a fresh config.
+func newConfig(opts []Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// applyHooks registers cfg's accumulated hooks against r, plus the built-in
+// cache-invalidation hook if WithCache was used.
+func (cfg *config) applyHooks(r hookRegistrar) {
+	for _, reg := range cfg.hooks {
+		r.RegisterHook(reg.point, reg.hook)
+	}
+	if cfg.cache != nil {
+		invalidate := hook.NewCacheInvalidationHook(cfg.cache)
+		r.RegisterHook(repository.AfterInsert, invalidate)
+		r.RegisterHook(repository.AfterUpdate, invalidate)
+		r.RegisterHook(repository.AfterDelete, invalidate)
+	}
+}